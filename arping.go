@@ -1,11 +1,14 @@
 // Package arping is a native go library to ping a host per arp datagram, or query a host mac address
 //
-// The currently supported platforms are: Linux and BSD.
+// The currently supported platforms are: Linux, BSD and Windows.
 //
 //
 // The library requires raw socket access. So it must run as root, or with appropriate capabilities under linux:
 // `sudo setcap cap_net_raw+ep <BIN>`.
 //
+// Under Windows, it's backed by Npcap (https://npcap.com) - install it
+// with the "WinPcap API-compatible Mode" option checked.
+//
 //
 // Examples:
 //
@@ -56,6 +59,24 @@
 //       }
 //     }
 //
+//
+//   scan a subnet:
+//   --------------
+//     package main
+//     import ("fmt"; "github.com/j-keck/arping"; "net")
+//
+//     func main(){
+//       _, cidr, _ := net.ParseCIDR("192.168.1.0/24")
+//       hosts, err := arping.Scan(cidr)
+//       if err != nil {
+//         fmt.Println(err)
+//         return
+//       }
+//       for ip, mac := range hosts {
+//         fmt.Printf("%s is at %s\n", ip, mac)
+//       }
+//     }
+//
 package arping
 
 import (
@@ -120,7 +141,6 @@ func PingWithOptions(dstIP net.IP, opts ...Option) (net.HardwareAddr, time.Durat
 	}
 	iface := *ops.iface
 	srcIP := ops.sourceIP
-	srcMac := iface.HardwareAddr
 
 	if len(srcIP) == 0 {
 		ip, err := findIPInNetworkFromIface(dstIP, iface)
@@ -130,60 +150,92 @@ func PingWithOptions(dstIP net.IP, opts ...Option) (net.HardwareAddr, time.Durat
 		srcIP = ip
 	}
 
-	broadcastMac := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
-	request := newArpRequest(srcMac, srcIP, broadcastMac, dstIP)
-
-	sock, err := initialize(iface)
+	session, err := NewSession(iface)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer sock.deinitialize()
+	defer session.Close()
 
-	type PingResult struct {
+	type pingResult struct {
 		mac      net.HardwareAddr
 		duration time.Duration
 		err      error
 	}
-	pingResultChan := make(chan PingResult, 1)
+	pingResultChan := make(chan pingResult, 1)
 
 	go func() {
 		// send arp request
 		verboseLog.Printf("arping '%s' over interface: '%s' with address: '%s'\n", dstIP, iface.Name, srcIP)
-		if sendTime, err := sock.send(request); err != nil {
-			pingResultChan <- PingResult{nil, 0, err}
-		} else {
-			for {
-				// receive arp response
-				response, receiveTime, err := sock.receive()
-
-				if err != nil {
-					pingResultChan <- PingResult{nil, 0, err}
-					return
-				}
-
-				if response.IsResponseOf(request) {
-					duration := receiveTime.Sub(sendTime)
-					verboseLog.Printf("process received arp: srcIP: '%s', srcMac: '%s'\n",
-						response.SenderIP(), response.SenderMac())
-					pingResultChan <- PingResult{response.SenderMac(), duration, err}
-					return
-				}
-
-				verboseLog.Printf("ignore received arp: srcIP: '%s', srcMac: '%s'\n",
+		request, sendTime, err := session.Send(srcIP, dstIP)
+		if err != nil {
+			pingResultChan <- pingResult{nil, 0, err}
+			return
+		}
+
+		for {
+			// receive arp response
+			response, receiveTime, err := session.Recv()
+
+			if err != nil {
+				pingResultChan <- pingResult{nil, 0, err}
+				return
+			}
+
+			if response.IsResponseOf(request) {
+				duration := receiveTime.Sub(sendTime)
+				verboseLog.Printf("process received arp: srcIP: '%s', srcMac: '%s'\n",
 					response.SenderIP(), response.SenderMac())
+				pingResultChan <- pingResult{response.SenderMac(), duration, err}
+				return
 			}
+
+			verboseLog.Printf("ignore received arp: srcIP: '%s', srcMac: '%s'\n",
+				response.SenderIP(), response.SenderMac())
 		}
 	}()
 
 	select {
-	case pingResult := <-pingResultChan:
-		return pingResult.mac, pingResult.duration, pingResult.err
+	case result := <-pingResultChan:
+		return result.mac, result.duration, result.err
 	case <-time.After(ops.timeout):
-		sock.deinitialize()
+		session.Close()
 		return nil, 0, ErrTimeout
 	}
 }
 
+// PingResult is the richer result of a ping, carrying resolved vendor
+// information alongside the (mac, duration) pair Ping/PingWithOptions
+// return.
+type PingResult struct {
+	IP       net.IP
+	MAC      net.HardwareAddr
+	Vendor   string
+	Duration time.Duration
+}
+
+// PingDetailed sends an arp ping to 'dstIP' like PingWithOptions, but
+// returns the richer PingResult - this is what WithVendorLookup needs in
+// order to report a resolved Vendor.
+func PingDetailed(dstIP net.IP, opts ...Option) (PingResult, error) {
+	ops := newOptions()
+	for _, opt := range opts {
+		if err := opt.apply(ops); err != nil {
+			return PingResult{}, err
+		}
+	}
+
+	mac, duration, err := PingWithOptions(dstIP, opts...)
+	if err != nil {
+		return PingResult{}, err
+	}
+
+	result := PingResult{IP: dstIP, MAC: mac, Duration: duration}
+	if ops.lookupVendor {
+		result.Vendor = resolveVendor(ops, mac)
+	}
+	return result, nil
+}
+
 // GratuitousArp sends an gratuitous arp from 'srcIP'
 func GratuitousArp(srcIP net.IP) error {
 	return GratuitousArpWithOptions(WithSourceIP(srcIP))
@@ -222,17 +274,13 @@ func GratuitousArpWithOptions(opts ...Option) error {
 	}
 	iface := *ops.iface
 
-	srcMac := iface.HardwareAddr
-	broadcastMac := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
-	request := newArpRequest(srcMac, srcIP, broadcastMac, srcIP)
-
-	sock, err := initialize(iface)
+	session, err := NewSession(iface)
 	if err != nil {
 		return err
 	}
-	defer sock.deinitialize()
+	defer session.Close()
 	verboseLog.Printf("gratuitous arp over interface: '%s' with address: '%s'\n", iface.Name, srcIP)
-	_, err = sock.send(request)
+	_, _, err = session.Send(srcIP, srcIP)
 	return err
 }
 
@@ -1,28 +1,129 @@
-// windows currently not supported.
-// dummy implementation to prevent compilation errors under windows
+//go:build windows
+
+// real windows support, backed by Npcap (https://npcap.com) via
+// gopacket/pcap. Npcap must be installed - with the "Install Npcap in
+// WinPcap API-compatible Mode" option checked - for this to find a
+// usable device.
 
 package arping
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
 )
 
-var errWindowsNotSupported = errors.New("arping under windows not supported")
+type pcapSocket struct {
+	handle *pcap.Handle
+	iface  net.Interface
+}
+
+func initialize(iface net.Interface) (*pcapSocket, error) {
+	device, err := findPcapDevice(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := pcap.OpenLive(device, 65536, true, pcap.BlockForever)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := handle.SetBPFFilter("arp"); err != nil {
+		handle.Close()
+		return nil, err
+	}
+
+	return &pcapSocket{handle: handle, iface: iface}, nil
+}
+
+func (s *pcapSocket) send(request arpDatagram) (time.Time, error) {
+	eth := layers.Ethernet{
+		SrcMAC:       request.SenderMac(),
+		DstMAC:       request.TargetMac(),
+		EthernetType: layers.EthernetTypeARP,
+	}
+	arp := layers.ARP{
+		AddrType:          layers.LinkTypeEthernet,
+		Protocol:          layers.EthernetTypeIPv4,
+		HwAddressSize:     6,
+		ProtAddressSize:   4,
+		Operation:         layers.ARPRequest,
+		SourceHwAddress:   []byte(request.SenderMac()),
+		SourceProtAddress: request.SenderIP().To4(),
+		DstHwAddress:      []byte(request.TargetMac()),
+		DstProtAddress:    request.TargetIP().To4(),
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true}, &eth, &arp); err != nil {
+		return time.Time{}, err
+	}
 
-func initialize(iface net.Interface) error {
-	return errWindowsNotSupported
+	sendTime := time.Now()
+	if err := s.handle.WritePacketData(buf.Bytes()); err != nil {
+		return time.Time{}, err
+	}
+	return sendTime, nil
 }
 
-func send(request arpDatagram) (time.Time, error) {
-	return new(time.Time), errWindowsNotSupported
+func (s *pcapSocket) receive() (arpDatagram, time.Time, error) {
+	data, ci, err := s.handle.ZeroCopyReadPacketData()
+	if err != nil {
+		return arpDatagram{}, time.Time{}, err
+	}
+
+	packet := gopacket.NewPacket(data, layers.LayerTypeEthernet, gopacket.NoCopy)
+	arpLayer := packet.Layer(layers.LayerTypeARP)
+	if arpLayer == nil {
+		return arpDatagram{}, time.Time{}, errors.New("received non-arp packet")
+	}
+	arp := arpLayer.(*layers.ARP)
+
+	response := newArpRequest(
+		net.HardwareAddr(arp.SourceHwAddress), net.IP(arp.SourceProtAddress),
+		net.HardwareAddr(arp.DstHwAddress), net.IP(arp.DstProtAddress))
+	return response, ci.Timestamp, nil
 }
 
-func receive() (arpDatagram, time.Time, error) {
-	return new(arpDatagram), new(time.Time), errWindowsNotSupported
+func (s *pcapSocket) deinitialize() error {
+	return s.handle.Close()
 }
 
-func deinitialize() error {
-	return errWindowsNotSupported
+// findPcapDevice maps a Go net.Interface to the pcap device name Npcap
+// expects. Npcap names devices by GUID ('\Device\NPF_{GUID}'), not by
+// the friendly name Go reports, so we match on a shared IP address
+// between 'iface.Addrs()' and 'pcap.FindAllDevs' instead.
+func findPcapDevice(iface net.Interface) (string, error) {
+	devices, err := pcap.FindAllDevs()
+	if err != nil {
+		return "", err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return "", err
+	}
+
+	ifaceIPs := make(map[string]bool, len(addrs))
+	for _, a := range addrs {
+		if ipnet, ok := a.(*net.IPNet); ok {
+			ifaceIPs[ipnet.IP.String()] = true
+		}
+	}
+
+	for _, device := range devices {
+		for _, addr := range device.Addresses {
+			if ifaceIPs[addr.IP.String()] {
+				return device.Name, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no pcap device found for interface: '%s' - is Npcap installed?", iface.Name)
 }
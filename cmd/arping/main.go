@@ -12,6 +12,8 @@
 //   -U: unsolicited/gratuitous ARP mode
 //   -i: interface name to use
 //   -t: timeout - duration with unit - such as 100ms, 500ms, 1s ...
+//   -c: number of pings to send - defaults to 1
+//   -w: interval between pings, used with -c - such as 100ms, 500ms, 1s ...
 //
 //
 // exit code:
@@ -36,6 +38,8 @@ var (
 	gratuitousFlag = flag.Bool("U", false, "unsolicited/gratuitous ARP mode")
 	ifaceNameFlag  = flag.String("i", "", "interface name to use - autodetected if omitted")
 	timeoutFlag    = flag.Duration("t", 500*time.Millisecond, "timeout - such as 100ms, 500ms, 1s ...")
+	countFlag      = flag.Int("c", 1, "number of pings to send")
+	intervalFlag   = flag.Duration("w", 1*time.Second, "interval between pings, used with -c - such as 100ms, 500ms, 1s ...")
 )
 
 func main() {
@@ -55,6 +59,10 @@ func main() {
 	}
 	dstIP := net.ParseIP(flag.Arg(0))
 
+	if !*gratuitousFlag && *countFlag > 1 {
+		pingN(dstIP)
+	}
+
 	var hwAddr net.HardwareAddr
 	var durationNanos time.Duration
 	var err error
@@ -89,19 +97,54 @@ func main() {
 	}
 
 	// ping success
-	durationMicros := durationNanos / 1000
+	fmt.Printf("%s (%s) %s usec\n", dstIP, hwAddr, formatUsec(durationNanos))
+	os.Exit(0)
+}
 
-	var durationString string
-	if durationMicros > 1000 {
-		durationString = fmt.Sprintf("%d,%03d", durationMicros/1000, durationMicros%1000)
-	} else {
-		durationString = fmt.Sprintf("%d", durationMicros)
+// pingN drives '-c'/'-w' continuous ping mode and never returns - it
+// exits the process once the run finishes.
+func pingN(dstIP net.IP) {
+	var opts []arping.Option
+	if len(*ifaceNameFlag) > 0 {
+		opts = append(opts, arping.WithIfaceByName(*ifaceNameFlag))
 	}
+	opts = append(opts, arping.WithTimeout(*timeoutFlag))
 
-	fmt.Printf("%s (%s) %s usec\n", dstIP, hwAddr, durationString)
+	replyChan, statsChan := arping.PingN(dstIP, *countFlag, *intervalFlag, opts...)
+	for reply := range replyChan {
+		if reply.Err != nil {
+			fmt.Printf("seq=%d %s\n", reply.Seq, reply.Err)
+			continue
+		}
+		fmt.Printf("seq=%d %s (%s) %s usec\n", reply.Seq, dstIP, reply.MAC, formatUsec(reply.Duration))
+	}
+
+	stats := <-statsChan
+	fmt.Printf("\n--- %s arping statistics ---\n", dstIP)
+	fmt.Printf("%d packets transmitted, %d received, %d lost\n", stats.Sent, stats.Received, stats.Lost)
+	if stats.Received > 0 {
+		fmt.Printf("rtt min/avg/max/stddev = %s/%s/%s/%s usec\n",
+			formatUsec(stats.MinRTT), formatUsec(stats.AvgRTT), formatUsec(stats.MaxRTT), formatUsec(stats.StdDevRTT))
+	}
+
+	// nothing was sent: the run failed before its first ping
+	if stats.Sent == 0 {
+		os.Exit(2)
+	}
+	if stats.Received == 0 {
+		os.Exit(1)
+	}
 	os.Exit(0)
 }
 
+func formatUsec(d time.Duration) string {
+	durationMicros := d / 1000
+	if durationMicros > 1000 {
+		return fmt.Sprintf("%d,%03d", durationMicros/1000, durationMicros%1000)
+	}
+	return fmt.Sprintf("%d", durationMicros)
+}
+
 func printHelpAndExit() {
 	fmt.Printf("Usage: %s <FLAGS> <IP>\n\n", os.Args[0])
 	flag.PrintDefaults()
@@ -0,0 +1,124 @@
+package arping
+
+import (
+	"math/rand"
+	"net"
+	"time"
+)
+
+const (
+	defaultProbeCount = 3
+	probeMin          = 1 * time.Second
+	probeMax          = 2 * time.Second
+	announceInterval  = 2 * time.Second
+)
+
+// DetectDuplicate implements RFC 5227-style IPv4 Address Conflict
+// Detection: it sends 'WithProbeCount' arp probes for 'candidateIP' -
+// sender IP 0.0.0.0, sender MAC the interface's MAC - spaced by a random
+// interval in [1s, 2s], and reports whether another host already claims
+// the address. Callers configuring a static IP should run this before
+// 'AnnounceAddress'.
+func DetectDuplicate(candidateIP net.IP, opts ...Option) (bool, net.HardwareAddr, error) {
+	if err := validateIP(candidateIP); err != nil {
+		return false, nil, err
+	}
+
+	ops := newOptions()
+	for _, opt := range opts {
+		if err := opt.apply(ops); err != nil {
+			return false, nil, err
+		}
+	}
+
+	if ops.iface == nil {
+		iface, err := findUsableInterfaceForNetwork(candidateIP)
+		if err != nil {
+			return false, nil, err
+		}
+		ops.iface = iface
+	}
+	iface := *ops.iface
+
+	session, err := NewSession(iface)
+	if err != nil {
+		return false, nil, err
+	}
+	defer session.Close()
+
+	conflictChan := make(chan net.HardwareAddr, 1)
+	go func() {
+		for {
+			response, _, err := session.Recv()
+			if err != nil {
+				return
+			}
+
+			if response.SenderMac().String() == iface.HardwareAddr.String() {
+				// our own probe, looped back by the promiscuous capture -
+				// not a conflict.
+				continue
+			}
+
+			if mac := probeConflictMac(response, candidateIP); mac != nil {
+				select {
+				case conflictChan <- mac:
+				default:
+				}
+				return
+			}
+		}
+	}()
+
+	probeCount := ops.probeCount
+	if probeCount <= 0 {
+		probeCount = defaultProbeCount
+	}
+
+	for i := 0; i < probeCount; i++ {
+		if _, _, err := session.Send(net.IPv4zero, candidateIP); err != nil {
+			return false, nil, err
+		}
+
+		wait := probeMin + time.Duration(rand.Int63n(int64(probeMax-probeMin)))
+		select {
+		case mac := <-conflictChan:
+			return true, mac, nil
+		case <-time.After(wait):
+		}
+	}
+
+	select {
+	case mac := <-conflictChan:
+		return true, mac, nil
+	case <-time.After(ops.timeout):
+		return false, nil, nil
+	}
+}
+
+// probeConflictMac reports the sender MAC of a conflicting arp datagram
+// for 'candidateIP', or nil if 'response' doesn't conflict. A conflict is
+// either a reply claiming 'candidateIP', or another host's probe for the
+// same address (sender IP 0.0.0.0, target IP 'candidateIP').
+func probeConflictMac(response arpDatagram, candidateIP net.IP) net.HardwareAddr {
+	if response.SenderIP().Equal(candidateIP) {
+		return response.SenderMac()
+	}
+	if response.SenderIP().Equal(net.IPv4zero) && response.TargetIP().Equal(candidateIP) {
+		return response.SenderMac()
+	}
+	return nil
+}
+
+// AnnounceAddress emits the standard 2 gratuitous ANNOUNCE arps for 'ip',
+// 2 seconds apart, as recommended by RFC 5227 after a successful
+// 'DetectDuplicate'.
+func AnnounceAddress(ip net.IP, opts ...Option) error {
+	if err := GratuitousArpWithOptions(append(opts, WithSourceIP(ip))...); err != nil {
+		return err
+	}
+
+	time.Sleep(announceInterval)
+
+	return GratuitousArpWithOptions(append(opts, WithSourceIP(ip))...)
+}
@@ -6,7 +6,7 @@ import (
 	"net"
 )
 
-func findIpInNetworkFromIface(dstIp net.IP, iface net.Interface) (net.IP, error) {
+func findIPInNetworkFromIface(dstIp net.IP, iface net.Interface) (net.IP, error) {
 	if addrs, err := iface.Addrs(); err != nil {
 		return nil, err
 	} else {
@@ -21,6 +21,45 @@ func findIpInNetworkFromIface(dstIp net.IP, iface net.Interface) (net.IP, error)
 	}
 }
 
+// hostAddresses enumerates every usable host address in 'cidr', skipping
+// the network and broadcast addresses.
+func hostAddresses(cidr *net.IPNet) []net.IP {
+	network := cidr.IP.Mask(cidr.Mask).To4()
+	if network == nil {
+		return nil
+	}
+
+	broadcast := make(net.IP, len(network))
+	for i := range network {
+		broadcast[i] = network[i] | ^cidr.Mask[i]
+	}
+
+	var ips []net.IP
+	for cur := cloneIP(network); !cur.Equal(broadcast); incIP(cur) {
+		if !cur.Equal(network) {
+			ips = append(ips, cloneIP(cur))
+		}
+	}
+	return ips
+}
+
+func cloneIP(ip net.IP) net.IP {
+	dup := make(net.IP, len(ip))
+	copy(dup, ip)
+	return dup
+}
+
+// incIP increments 'ip' in place, carrying into the preceding byte on
+// overflow - the standard way to walk every address in a CIDR range.
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			break
+		}
+	}
+}
+
 func findUsableInterfaceForNetwork(dstIp net.IP) (*net.Interface, error) {
 	if ifaces, err := net.Interfaces(); err != nil {
 		return nil, err
@@ -30,7 +69,7 @@ func findUsableInterfaceForNetwork(dstIp net.IP) (*net.Interface, error) {
 		}
 
 		hasAddressInNetwork := func(iface net.Interface) bool {
-			if _, err := findIpInNetworkFromIface(dstIp, iface); err != nil {
+			if _, err := findIPInNetworkFromIface(dstIp, iface); err != nil {
 				return false
 			}
 			return true
@@ -0,0 +1,65 @@
+package arping
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHostAddressesSkipsNetworkAndBroadcast(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("192.168.1.0/30")
+	if err != nil {
+		t.Fatalf("ParseCIDR failed: %s", err)
+	}
+
+	ips := hostAddresses(cidr)
+
+	expected := []string{"192.168.1.1", "192.168.1.2"}
+	if len(ips) != len(expected) {
+		t.Fatalf("expected %d addresses, got %d: %v", len(expected), len(ips), ips)
+	}
+	for i, want := range expected {
+		if ips[i].String() != want {
+			t.Errorf("ips[%d] = %s, want %s", i, ips[i], want)
+		}
+	}
+}
+
+func TestHostAddressesSlash24(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR failed: %s", err)
+	}
+
+	ips := hostAddresses(cidr)
+
+	if len(ips) != 254 {
+		t.Fatalf("expected 254 usable addresses in a /24, got %d", len(ips))
+	}
+	if ips[0].String() != "10.0.0.1" {
+		t.Errorf("first address = %s, want 10.0.0.1", ips[0])
+	}
+	if ips[len(ips)-1].String() != "10.0.0.254" {
+		t.Errorf("last address = %s, want 10.0.0.254", ips[len(ips)-1])
+	}
+}
+
+func TestIncIPCarries(t *testing.T) {
+	ip := net.ParseIP("192.168.1.255").To4()
+	incIP(ip)
+	if ip.String() != "192.168.2.0" {
+		t.Errorf("incIP carried wrong: got %s, want 192.168.2.0", ip)
+	}
+}
+
+func TestIncIPDoesNotMutateClone(t *testing.T) {
+	original := net.ParseIP("10.0.0.1").To4()
+	dup := cloneIP(original)
+	incIP(dup)
+
+	if original.String() != "10.0.0.1" {
+		t.Errorf("incIP on the clone mutated the original: %s", original)
+	}
+	if dup.String() != "10.0.0.2" {
+		t.Errorf("clone not incremented: got %s, want 10.0.0.2", dup)
+	}
+}
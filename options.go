@@ -6,9 +6,13 @@ import (
 )
 
 type options struct {
-	iface    *net.Interface
-	sourceIP net.IP
-	timeout  time.Duration
+	iface        *net.Interface
+	sourceIP     net.IP
+	timeout      time.Duration
+	deadline     time.Duration
+	lookupVendor bool
+	vendorDBPath string
+	probeCount   int
 }
 
 func newOptions() *options {
@@ -75,3 +79,61 @@ func (n duration) apply(opts *options) error {
 func WithTimeout(timeout time.Duration) Option {
 	return duration(timeout)
 }
+
+type deadline time.Duration
+
+func (n deadline) apply(opts *options) error {
+	opts.deadline = time.Duration(n)
+	return nil
+}
+
+// WithDeadline sets an overall deadline for ScanChan/Scan - once it
+// elapses the scan stops, even if not every target has been probed yet.
+// It has no effect on Ping/PingWithOptions/GratuitousArpWithOptions.
+func WithDeadline(d time.Duration) Option {
+	return deadline(d)
+}
+
+type vendorLookup struct{}
+
+func (vendorLookup) apply(opts *options) error {
+	opts.lookupVendor = true
+	return nil
+}
+
+// WithVendorLookup resolves and populates the Vendor field of the
+// PingResult/ScanResult returned by PingDetailed/ScanChan. It has no
+// effect on Scan, whose map[string]net.HardwareAddr return type has no
+// field to carry a Vendor in - use ScanChan if you need it.
+func WithVendorLookup() Option {
+	return vendorLookup{}
+}
+
+type vendorDatabase string
+
+func (p vendorDatabase) apply(opts *options) error {
+	opts.vendorDBPath = string(p)
+	opts.lookupVendor = true
+	return nil
+}
+
+// WithVendorDatabase is like WithVendorLookup, but resolves vendors
+// against the 'manuf'-style database at 'path' instead of the small
+// embedded one - point it at Wireshark's 'manuf' file to keep the
+// lookup table fresh.
+func WithVendorDatabase(path string) Option {
+	return vendorDatabase(path)
+}
+
+type probeCount int
+
+func (n probeCount) apply(opts *options) error {
+	opts.probeCount = int(n)
+	return nil
+}
+
+// WithProbeCount sets the number of arp probes 'DetectDuplicate' sends
+// before declaring an address free. Defaults to 3.
+func WithProbeCount(n int) Option {
+	return probeCount(n)
+}
@@ -0,0 +1,252 @@
+package arping
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// PingReply is a single reply - or timeout - from a PingN run.
+type PingReply struct {
+	Seq      int
+	MAC      net.HardwareAddr
+	Duration time.Duration
+	Err      error
+}
+
+// PingStats summarizes a finished PingN run.
+type PingStats struct {
+	Sent      int
+	Received  int
+	Lost      int
+	MinRTT    time.Duration
+	MaxRTT    time.Duration
+	AvgRTT    time.Duration
+	StdDevRTT time.Duration
+}
+
+// PingN sends 'count' arp pings to 'dstIP', 'interval' apart, reusing a
+// single raw socket for the whole run - repeated single-shot Ping calls
+// tear the socket down every time, which is expensive and racy for
+// link-monitoring or availability-check use cases. Each reply (or
+// per-ping timeout) is published on the returned reply channel as it
+// happens; once the run finishes, a final PingStats is published on the
+// stats channel and both channels are closed.
+func PingN(dstIP net.IP, count int, interval time.Duration, opts ...Option) (<-chan PingReply, <-chan PingStats) {
+	// a negative count is invalid, but must not reach 'make' below -
+	// a negative channel capacity panics the caller's process. The
+	// capacity is at least 1 so 'fail' can publish its error before the
+	// caller starts reading.
+	bufSize := count
+	if bufSize < 1 {
+		bufSize = 1
+	}
+	replyChan := make(chan PingReply, bufSize)
+	statsChan := make(chan PingStats, 1)
+
+	fail := func(err error) {
+		replyChan <- PingReply{Err: err}
+		statsChan <- PingStats{}
+		close(replyChan)
+		close(statsChan)
+	}
+
+	if err := validateIP(dstIP); err != nil {
+		fail(err)
+		return replyChan, statsChan
+	}
+
+	if count < 0 {
+		fail(fmt.Errorf("count must be >= 0, got: %d", count))
+		return replyChan, statsChan
+	}
+
+	ops := newOptions()
+	for _, opt := range opts {
+		if err := opt.apply(ops); err != nil {
+			fail(err)
+			return replyChan, statsChan
+		}
+	}
+
+	if ops.iface == nil {
+		iface, err := findUsableInterfaceForNetwork(dstIP)
+		if err != nil {
+			fail(err)
+			return replyChan, statsChan
+		}
+		ops.iface = iface
+	}
+	iface := *ops.iface
+
+	srcIP := ops.sourceIP
+	if len(srcIP) == 0 {
+		ip, err := findIPInNetworkFromIface(dstIP, iface)
+		if err != nil {
+			fail(err)
+			return replyChan, statsChan
+		}
+		srcIP = ip
+	}
+
+	session, err := NewSession(iface)
+	if err != nil {
+		fail(err)
+		return replyChan, statsChan
+	}
+
+	go func() {
+		defer close(replyChan)
+		defer close(statsChan)
+
+		responseChan := make(chan arpObservation)
+		recvErrChan := make(chan error, 1)
+		done := make(chan struct{})
+
+		// gen identifies the probe currently awaiting a reply. The
+		// receiver goroutine stamps every captured packet with the
+		// generation active at capture time, so waitForReply can
+		// recognize a reply for an earlier, already-abandoned probe -
+		// still in flight because it arrived after that probe's own
+		// timeout - and discard it instead of misattributing it to the
+		// probe it's currently waiting on.
+		var gen int64
+
+		// single long-lived receiver goroutine for the whole run - the
+		// send loop below matches each reply against the request it's
+		// currently waiting on.
+		go func() {
+			for {
+				response, receiveTime, err := session.Recv()
+				if err != nil {
+					select {
+					case recvErrChan <- err:
+					case <-done:
+					}
+					return
+				}
+				obs := arpObservation{response, receiveTime, atomic.LoadInt64(&gen)}
+				select {
+				case responseChan <- obs:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		var stats rttAccumulator
+		for seq := 0; seq < count; seq++ {
+			if seq > 0 {
+				time.Sleep(interval)
+			}
+
+			myGen := atomic.AddInt64(&gen, 1)
+
+			request, sendTime, err := session.Send(srcIP, dstIP)
+			stats.sent++
+			if err != nil {
+				replyChan <- PingReply{Seq: seq, Err: err}
+				continue
+			}
+
+			mac, duration, err := waitForReply(request, myGen, sendTime, ops.timeout, responseChan, recvErrChan)
+			if err != nil {
+				replyChan <- PingReply{Seq: seq, Err: err}
+				continue
+			}
+
+			stats.record(duration)
+			replyChan <- PingReply{Seq: seq, MAC: mac, Duration: duration}
+		}
+
+		close(done)
+		session.Close()
+		statsChan <- stats.stats()
+	}()
+
+	return replyChan, statsChan
+}
+
+// arpObservation pairs an arp datagram with the wall-clock time it was
+// actually captured at, so RTTs are measured against the real arrival
+// time rather than when a goroutine happened to process it, plus the
+// generation of the probe that was outstanding at capture time.
+type arpObservation struct {
+	datagram    arpDatagram
+	receiveTime time.Time
+	gen         int64
+}
+
+// waitForReply blocks until a reply matching 'request' arrives, the
+// receiver goroutine reports a socket error, or 'timeout' elapses -
+// whichever comes first. 'gen' is the generation assigned to this probe;
+// 'newArpRequest'/'IsResponseOf' match purely on IP/MAC fields, with no
+// per-probe nonce, so a reply for an earlier, already-abandoned probe
+// can still arrive while we're waiting on a later one. Such a reply is
+// stamped with the generation that was current when it was captured, so
+// a mismatch against 'gen' identifies it as stale and it's discarded
+// instead of being misattributed to this probe.
+func waitForReply(request arpDatagram, gen int64, sendTime time.Time, timeout time.Duration, responseChan <-chan arpObservation, recvErrChan <-chan error) (net.HardwareAddr, time.Duration, error) {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case obs := <-responseChan:
+			if obs.gen != gen {
+				// reply for an earlier, already-abandoned probe.
+				continue
+			}
+			if obs.datagram.IsResponseOf(request) {
+				return obs.datagram.SenderMac(), obs.receiveTime.Sub(sendTime), nil
+			}
+		case err := <-recvErrChan:
+			return nil, 0, err
+		case <-deadline:
+			return nil, 0, ErrTimeout
+		}
+	}
+}
+
+// rttAccumulator computes running min/max/avg/stddev of RTTs via
+// Welford's online algorithm, so a PingN run doesn't need to retain the
+// full RTT slice to report StdDevRTT.
+type rttAccumulator struct {
+	sent     int
+	received int
+	min      time.Duration
+	max      time.Duration
+	mean     float64
+	m2       float64
+}
+
+func (a *rttAccumulator) record(d time.Duration) {
+	a.received++
+	if a.received == 1 || d < a.min {
+		a.min = d
+	}
+	if a.received == 1 || d > a.max {
+		a.max = d
+	}
+
+	delta := float64(d) - a.mean
+	a.mean += delta / float64(a.received)
+	a.m2 += delta * (float64(d) - a.mean)
+}
+
+func (a *rttAccumulator) stats() PingStats {
+	var stdDev time.Duration
+	if a.received > 1 {
+		stdDev = time.Duration(math.Sqrt(a.m2 / float64(a.received)))
+	}
+
+	return PingStats{
+		Sent:      a.sent,
+		Received:  a.received,
+		Lost:      a.sent - a.received,
+		MinRTT:    a.min,
+		MaxRTT:    a.max,
+		AvgRTT:    time.Duration(a.mean),
+		StdDevRTT: stdDev,
+	}
+}
@@ -0,0 +1,77 @@
+package arping
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRttAccumulatorStats(t *testing.T) {
+	var acc rttAccumulator
+	acc.sent = 4
+
+	samples := []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		30 * time.Millisecond,
+	}
+	for _, s := range samples {
+		acc.record(s)
+	}
+
+	stats := acc.stats()
+
+	if stats.Sent != 4 {
+		t.Errorf("Sent = %d, want 4", stats.Sent)
+	}
+	if stats.Received != 3 {
+		t.Errorf("Received = %d, want 3", stats.Received)
+	}
+	if stats.Lost != 1 {
+		t.Errorf("Lost = %d, want 1", stats.Lost)
+	}
+	if stats.MinRTT != 10*time.Millisecond {
+		t.Errorf("MinRTT = %s, want 10ms", stats.MinRTT)
+	}
+	if stats.MaxRTT != 30*time.Millisecond {
+		t.Errorf("MaxRTT = %s, want 30ms", stats.MaxRTT)
+	}
+	if stats.AvgRTT != 20*time.Millisecond {
+		t.Errorf("AvgRTT = %s, want 20ms", stats.AvgRTT)
+	}
+
+	// population stddev of {10, 20, 30}ms is sqrt(200/3) ~= 8.16ms
+	wantStdDev := time.Duration(math.Sqrt(200.0/3.0) * float64(time.Millisecond))
+	if diff := stats.StdDevRTT - wantStdDev; diff < -time.Microsecond || diff > time.Microsecond {
+		t.Errorf("StdDevRTT = %s, want ~%s", stats.StdDevRTT, wantStdDev)
+	}
+}
+
+func TestRttAccumulatorNoReplies(t *testing.T) {
+	var acc rttAccumulator
+	acc.sent = 3
+
+	stats := acc.stats()
+
+	if stats.Received != 0 || stats.Lost != 3 {
+		t.Errorf("got Received=%d Lost=%d, want Received=0 Lost=3", stats.Received, stats.Lost)
+	}
+	if stats.StdDevRTT != 0 {
+		t.Errorf("StdDevRTT = %s, want 0 with no replies", stats.StdDevRTT)
+	}
+}
+
+func TestRttAccumulatorSingleReply(t *testing.T) {
+	var acc rttAccumulator
+	acc.sent = 1
+	acc.record(15 * time.Millisecond)
+
+	stats := acc.stats()
+
+	if stats.MinRTT != 15*time.Millisecond || stats.MaxRTT != 15*time.Millisecond {
+		t.Errorf("min/max = %s/%s, want 15ms/15ms", stats.MinRTT, stats.MaxRTT)
+	}
+	if stats.StdDevRTT != 0 {
+		t.Errorf("StdDevRTT = %s, want 0 for a single sample", stats.StdDevRTT)
+	}
+}
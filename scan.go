@@ -0,0 +1,194 @@
+package arping
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// ScanResult is a single responder discovered by 'Scan' / 'ScanChan'.
+type ScanResult struct {
+	IP       net.IP
+	MAC      net.HardwareAddr
+	Vendor   string
+	Duration time.Duration
+}
+
+// Scan sends an arp request to every usable host address in 'cidr' and
+// collects the responders into a map keyed by their IP address.
+func Scan(cidr *net.IPNet, opts ...Option) (map[string]net.HardwareAddr, error) {
+	resultChan, err := ScanChan(cidr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]net.HardwareAddr)
+	for result := range resultChan {
+		results[result.IP.String()] = result.MAC
+	}
+	return results, nil
+}
+
+// ScanChan sweeps every usable host address in 'cidr' for arp responders,
+// streaming each discovered host on the returned channel as soon as it
+// replies. A reply arriving more than the per-target timeout (see
+// 'WithTimeout') after its own request was sent is treated as if that
+// target never answered. The channel is closed once the scan's overall
+// deadline elapses - see 'WithDeadline'. If no deadline is given, one is
+// derived from the per-target timeout and the number of targets.
+//
+// Internally a single raw socket per interface is reused for the whole
+// sweep - one goroutine sends requests, another receives replies, and a
+// third ages out targets that didn't answer in time - rather than
+// opening a socket per target.
+func ScanChan(cidr *net.IPNet, opts ...Option) (<-chan ScanResult, error) {
+	ops := newOptions()
+	for _, opt := range opts {
+		if err := opt.apply(ops); err != nil {
+			return nil, err
+		}
+	}
+
+	if ops.iface == nil {
+		iface, err := findUsableInterfaceForNetwork(cidr.IP)
+		if err != nil {
+			return nil, err
+		}
+		ops.iface = iface
+	}
+	iface := *ops.iface
+
+	srcIP := ops.sourceIP
+	if len(srcIP) == 0 {
+		ip, err := findIPInNetworkFromIface(cidr.IP, iface)
+		if err != nil {
+			return nil, err
+		}
+		srcIP = ip
+	}
+
+	session, err := NewSession(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := hostAddresses(cidr)
+
+	deadline := ops.deadline
+	if deadline <= 0 {
+		deadline = time.Duration(len(targets)) * ops.timeout
+	}
+
+	out := make(chan ScanResult)
+	done := make(chan struct{})
+
+	sent := make(map[string]time.Time, len(targets))
+	var sentMu sync.Mutex
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	// receiver goroutine: matches every incoming reply against the
+	// targets we've sent a request to so far.
+	go func() {
+		defer wg.Done()
+		for {
+			response, receiveTime, err := session.Recv()
+			if err != nil {
+				return
+			}
+
+			ip := response.SenderIP()
+			sentMu.Lock()
+			sendTime, ok := sent[ip.String()]
+			sentMu.Unlock()
+			if !ok {
+				verboseLog.Printf("scan: ignore unsolicited arp from '%s'\n", ip)
+				continue
+			}
+
+			rtt := receiveTime.Sub(sendTime)
+			if rtt > ops.timeout {
+				verboseLog.Printf("scan: ignore late arp from '%s'\n", ip)
+				continue
+			}
+
+			mac := response.SenderMac()
+			result := ScanResult{IP: ip, MAC: mac, Duration: rtt}
+			if ops.lookupVendor {
+				result.Vendor = resolveVendor(ops, mac)
+			}
+
+			select {
+			case out <- result:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// sender goroutine: walks every target address in the cidr.
+	go func() {
+		defer wg.Done()
+		for _, ip := range targets {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			_, sendTime, err := session.Send(srcIP, ip)
+			if err != nil {
+				verboseLog.Printf("scan: send to '%s' failed: %s\n", ip, err)
+				continue
+			}
+
+			sentMu.Lock()
+			sent[ip.String()] = sendTime
+			sentMu.Unlock()
+		}
+	}()
+
+	// sweeper goroutine: ages out targets whose per-target timeout has
+	// elapsed with no reply, so a reply that eventually trickles in late
+	// is ignored as unsolicited rather than accepted.
+	go func() {
+		defer wg.Done()
+
+		sweepInterval := ops.timeout / 4
+		if sweepInterval < time.Millisecond {
+			sweepInterval = time.Millisecond
+		}
+
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				sentMu.Lock()
+				for ip, sendTime := range sent {
+					if now.Sub(sendTime) > ops.timeout {
+						delete(sent, ip)
+					}
+				}
+				sentMu.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		timer := time.NewTimer(deadline)
+		defer timer.Stop()
+		<-timer.C
+
+		close(done)
+		session.Close()
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package arping
+
+import (
+	"net"
+	"time"
+)
+
+// socketHandle is the subset of the per-platform raw socket type a
+// Session needs - the 'send' / 'receive' / 'deinitialize' trio each
+// platform backend implements.
+type socketHandle interface {
+	send(arpDatagram) (time.Time, error)
+	receive() (arpDatagram, time.Time, error)
+	deinitialize() error
+}
+
+// Session is a raw socket bound to a single interface which stays open
+// across multiple requests. Ping opens and tears down a socket on every
+// call, which is wasteful for callers sending many requests in a row -
+// Scan and PingN use a Session instead so a sweep of a whole subnet
+// needs a single socket per interface rather than one per target.
+type Session struct {
+	iface net.Interface
+	sock  socketHandle
+}
+
+// NewSession opens a raw socket on 'iface' for repeated use. Callers
+// must 'Close' the session once done with it.
+func NewSession(iface net.Interface) (*Session, error) {
+	sock, err := initialize(iface)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{iface: iface, sock: sock}, nil
+}
+
+// Send sends an arp request for 'dstIP' from 'srcIP' over the session's
+// interface. It returns the request which was put on the wire alongside
+// the time it was sent, so the caller can later match it against
+// incoming replies with 'arpDatagram.IsResponseOf'.
+func (s *Session) Send(srcIP, dstIP net.IP) (arpDatagram, time.Time, error) {
+	broadcastMac := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	request := newArpRequest(s.iface.HardwareAddr, srcIP, broadcastMac, dstIP)
+	sendTime, err := s.sock.send(request)
+	return request, sendTime, err
+}
+
+// Recv blocks until the next arp datagram arrives on the session.
+func (s *Session) Recv() (arpDatagram, time.Time, error) {
+	return s.sock.receive()
+}
+
+// Close releases the session's raw socket. Closing a session unblocks
+// any goroutine currently parked in 'Recv'.
+func (s *Session) Close() error {
+	return s.sock.deinitialize()
+}
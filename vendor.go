@@ -0,0 +1,156 @@
+package arping
+
+import (
+	"bufio"
+	_ "embed"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/manuf.txt
+var embeddedOUIDatabase string
+
+var defaultOUIDatabase = parseOUIDatabase(embeddedOUIDatabase)
+
+// ouiEntry is a single IEEE OUI prefix -> vendor assignment. 'bits' is
+// the number of significant bits in 'prefix' - 24 for MA-L, 28 for MA-M,
+// 36 for MA-S.
+type ouiEntry struct {
+	prefix net.HardwareAddr
+	bits   int
+	vendor string
+}
+
+// LookupVendor resolves the vendor which owns 'mac' by matching it
+// against an embedded, trimmed IEEE OUI database (MA-L/MA-M/MA-S),
+// preferring the longest matching prefix. It returns "" if nothing
+// matches - use 'WithVendorDatabase' to load a fuller database, such as
+// Wireshark's 'manuf' file, for broader coverage.
+func LookupVendor(mac net.HardwareAddr) string {
+	return lookupVendorIn(defaultOUIDatabase, mac)
+}
+
+func lookupVendorIn(db []ouiEntry, mac net.HardwareAddr) string {
+	vendor := ""
+	bestBits := -1
+	for _, entry := range db {
+		if entry.bits <= bestBits {
+			continue
+		}
+		if matchesOUIPrefix(mac, entry.prefix, entry.bits) {
+			bestBits = entry.bits
+			vendor = entry.vendor
+		}
+	}
+	return vendor
+}
+
+func matchesOUIPrefix(mac, prefix net.HardwareAddr, bits int) bool {
+	if len(mac) < len(prefix) {
+		return false
+	}
+
+	fullBytes := bits / 8
+	for i := 0; i < fullBytes; i++ {
+		if mac[i] != prefix[i] {
+			return false
+		}
+	}
+
+	if remBits := bits % 8; remBits > 0 {
+		shift := uint(8 - remBits)
+		if mac[fullBytes]>>shift != prefix[fullBytes]>>shift {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveVendor looks 'mac' up using the database selected by 'ops' -
+// the embedded one by default, or the file at 'ops.vendorDBPath' if
+// 'WithVendorDatabase' was given.
+func resolveVendor(ops *options, mac net.HardwareAddr) string {
+	if ops.vendorDBPath == "" {
+		return LookupVendor(mac)
+	}
+
+	db, err := loadOUIDatabase(ops.vendorDBPath)
+	if err != nil {
+		verboseLog.Printf("vendor lookup: failed to load '%s': %s\n", ops.vendorDBPath, err)
+		return LookupVendor(mac)
+	}
+	return lookupVendorIn(db, mac)
+}
+
+func loadOUIDatabase(path string) ([]ouiEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseOUIDatabase(string(data)), nil
+}
+
+func parseOUIDatabase(data string) []ouiEntry {
+	var entries []ouiEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		prefix, bits, err := parseOUIPrefix(fields[0])
+		if err != nil {
+			verboseLog.Printf("vendor database: skip malformed entry '%s': %s\n", fields[0], err)
+			continue
+		}
+
+		entries = append(entries, ouiEntry{prefix: prefix, bits: bits, vendor: strings.TrimSpace(fields[1])})
+	}
+
+	return entries
+}
+
+// parseOUIPrefix parses a 'manuf'-style prefix such as "00:1A:11" (a
+// plain 24 bit MA-L OUI) or "70:B3:D5:70:0/28" (an explicit-bit-count
+// MA-M/MA-S prefix) into a zero-padded 6 byte address and its
+// significant bit count.
+func parseOUIPrefix(spec string) (net.HardwareAddr, int, error) {
+	groupSpec := spec
+	bits := 0
+	if idx := strings.IndexByte(spec, '/'); idx >= 0 {
+		groupSpec = spec[:idx]
+		n, err := strconv.Atoi(spec[idx+1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		bits = n
+	}
+
+	groups := strings.Split(groupSpec, ":")
+	if bits == 0 {
+		bits = len(groups) * 8
+	}
+
+	prefix := make(net.HardwareAddr, 6)
+	for i, g := range groups {
+		if i >= len(prefix) {
+			break
+		}
+		b, err := strconv.ParseUint(g, 16, 8)
+		if err != nil {
+			return nil, 0, err
+		}
+		prefix[i] = byte(b)
+	}
+
+	return prefix, bits, nil
+}
@@ -0,0 +1,77 @@
+package arping
+
+import (
+	"net"
+	"testing"
+)
+
+func TestParseOUIPrefixMAL(t *testing.T) {
+	prefix, bits, err := parseOUIPrefix("00:1A:11")
+	if err != nil {
+		t.Fatalf("parseOUIPrefix failed: %s", err)
+	}
+	if bits != 24 {
+		t.Errorf("bits = %d, want 24", bits)
+	}
+	want := net.HardwareAddr{0x00, 0x1a, 0x11, 0x00, 0x00, 0x00}
+	if prefix.String() != want.String() {
+		t.Errorf("prefix = %s, want %s", prefix, want)
+	}
+}
+
+func TestParseOUIPrefixExplicitBits(t *testing.T) {
+	prefix, bits, err := parseOUIPrefix("70:B3:D5:70:0/28")
+	if err != nil {
+		t.Fatalf("parseOUIPrefix failed: %s", err)
+	}
+	if bits != 28 {
+		t.Errorf("bits = %d, want 28", bits)
+	}
+	want := net.HardwareAddr{0x70, 0xb3, 0xd5, 0x70, 0x00, 0x00}
+	if prefix.String() != want.String() {
+		t.Errorf("prefix = %s, want %s", prefix, want)
+	}
+}
+
+func TestParseOUIPrefixInvalid(t *testing.T) {
+	if _, _, err := parseOUIPrefix("not-hex"); err == nil {
+		t.Error("error expected for malformed prefix")
+	}
+}
+
+func TestMatchesOUIPrefix(t *testing.T) {
+	prefix := net.HardwareAddr{0x70, 0xb3, 0xd5, 0x70, 0x00, 0x00}
+
+	matching := net.HardwareAddr{0x70, 0xb3, 0xd5, 0x7f, 0x11, 0x22}
+	if !matchesOUIPrefix(matching, prefix, 28) {
+		t.Errorf("%s should match /28 prefix %s", matching, prefix)
+	}
+
+	nonMatching := net.HardwareAddr{0x70, 0xb3, 0xd5, 0x80, 0x11, 0x22}
+	if matchesOUIPrefix(nonMatching, prefix, 28) {
+		t.Errorf("%s should not match /28 prefix %s", nonMatching, prefix)
+	}
+}
+
+func TestLookupVendorInPrefersLongestMatch(t *testing.T) {
+	db := []ouiEntry{
+		{prefix: net.HardwareAddr{0x70, 0xb3, 0xd5, 0x00, 0x00, 0x00}, bits: 24, vendor: "MA-L vendor"},
+		{prefix: net.HardwareAddr{0x70, 0xb3, 0xd5, 0x70, 0x00, 0x00}, bits: 28, vendor: "MA-M vendor"},
+	}
+
+	mac := net.HardwareAddr{0x70, 0xb3, 0xd5, 0x7f, 0x11, 0x22}
+	if vendor := lookupVendorIn(db, mac); vendor != "MA-M vendor" {
+		t.Errorf("lookupVendorIn = %q, want %q", vendor, "MA-M vendor")
+	}
+}
+
+func TestLookupVendorInNoMatch(t *testing.T) {
+	db := []ouiEntry{
+		{prefix: net.HardwareAddr{0x70, 0xb3, 0xd5, 0x00, 0x00, 0x00}, bits: 24, vendor: "some vendor"},
+	}
+
+	mac := net.HardwareAddr{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	if vendor := lookupVendorIn(db, mac); vendor != "" {
+		t.Errorf("lookupVendorIn = %q, want \"\"", vendor)
+	}
+}